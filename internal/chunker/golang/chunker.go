@@ -0,0 +1,271 @@
+// SPDX-FileCopyrightText: 2025 Knitli Inc.
+// SPDX-License-Identifier: MIT OR Apache-2.0
+
+// Package golang extracts semantically coherent chunks from Go source files
+// for embedding and search. It keeps declarations that belong together --
+// starting with a generic declaration's type parameter clause -- attached to
+// the chunk they modify instead of letting a naive split separate them.
+package golang
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"slices"
+)
+
+// ChunkerOptions configures chunk extraction.
+type ChunkerOptions struct {
+	// PreserveLockGroups detects struct fields typed sync.Mutex,
+	// sync.RWMutex, sync.Map, or sync/atomic's atomic.* types, and clusters
+	// the struct chunk with the methods that acquire them: every chunk in
+	// the cluster records the names of its siblings so a downstream split
+	// never separates lock-discipline code without a trace of what it
+	// belongs with.
+	PreserveLockGroups bool
+}
+
+// Chunker extracts Chunks from Go source files.
+type Chunker struct {
+	opts ChunkerOptions
+}
+
+// New creates a Chunker with the given options.
+func New(opts ChunkerOptions) *Chunker {
+	return &Chunker{opts: opts}
+}
+
+// chunkContext carries the per-file cross-reference tables computed before
+// chunking begins, so individual decl chunks can be annotated without
+// re-walking the file.
+type chunkContext struct {
+	rel              *relations
+	lockGroups       map[string][]string
+	singleflight     map[string]string
+	typeParamsByType map[string][]TypeParam
+}
+
+// ChunkFile parses src and returns one Chunk per top-level function, method,
+// and type declaration (one per spec of a grouped type block). Import, const,
+// and var declarations yield no chunk.
+func (c *Chunker) ChunkFile(filename string, src []byte) ([]Chunk, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := &chunkContext{
+		rel:              newSymbolTable(fset, src, file).implementations(),
+		singleflight:     singleflightTypes(file),
+		typeParamsByType: collectTypeParams(fset, src, file),
+	}
+	if c.opts.PreserveLockGroups {
+		ctx.lockGroups = lockGroups(file)
+	}
+
+	chunks := make([]Chunk, 0, len(file.Decls))
+	for _, decl := range file.Decls {
+		chunks = append(chunks, c.chunkDecl(fset, src, decl, ctx)...)
+	}
+
+	return chunks, nil
+}
+
+// chunkDecl returns the chunks for a single declaration. A GenDecl yields
+// more than one: a grouped `type ( A struct{...}; B interface{...} )` block
+// is one GenDecl with multiple TypeSpecs, and each must become its own
+// chunk rather than only the first.
+func (c *Chunker) chunkDecl(fset *token.FileSet, src []byte, decl ast.Decl, ctx *chunkContext) []Chunk {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		return []Chunk{c.chunkFunc(fset, src, d, ctx)}
+	case *ast.GenDecl:
+		if d.Tok != token.TYPE {
+			return nil
+		}
+		chunks := make([]Chunk, 0, len(d.Specs))
+		for _, s := range d.Specs {
+			spec, ok := s.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			chunks = append(chunks, c.chunkType(fset, src, d, spec, ctx))
+		}
+		return chunks
+	default:
+		return nil
+	}
+}
+
+// chunkFunc builds a Chunk for a func declaration, keeping any generic type
+// parameter clause (and generic method receiver) attached to the signature
+// rather than letting it be split off or dropped.
+func (c *Chunker) chunkFunc(fset *token.FileSet, src []byte, fn *ast.FuncDecl, ctx *chunkContext) Chunk {
+	kind := KindFunc
+	if fn.Recv != nil {
+		kind = KindMethod
+	}
+
+	chunk := Chunk{
+		Kind:      kind,
+		Name:      fn.Name.Name,
+		StartLine: fset.Position(fn.Pos()).Line,
+		EndLine:   fset.Position(fn.End()).Line,
+		Source:    sliceSource(fset, src, fn.Pos(), fn.End()),
+	}
+
+	switch {
+	case fn.Type.TypeParams != nil:
+		// A free generic function declares its own type parameter list.
+		chunk.Metadata.TypeParams = typeParams(fset, src, fn.Type.TypeParams)
+	case fn.Recv != nil && len(fn.Recv.List) > 0:
+		// A generic method re-lists its receiver's type parameter names
+		// (e.g. `func (c *Cache[K, V]) Get(...)`) but not their
+		// constraints, so look those up from where the type was declared.
+		recvExpr := fn.Recv.List[0].Type
+		if names := receiverTypeParamNames(recvExpr); len(names) > 0 {
+			recv := receiverTypeName(recvExpr)
+			chunk.Metadata.TypeParams = receiverTypeParams(names, ctx.typeParamsByType[recv])
+		}
+	}
+
+	if fn.Recv != nil && len(fn.Recv.List) > 0 {
+		recv := receiverTypeName(fn.Recv.List[0].Type)
+		chunk.Metadata.Implements = ctx.rel.implements[recv]
+		if group := ctx.lockGroups[recv]; slices.Contains(group, fn.Name.Name) {
+			chunk.Metadata.LockGroup = siblingsExcept(group, fn.Name.Name)
+		}
+		if field, ok := ctx.singleflight[recv]; ok && bodySpawnsGoroutine(fn.Body) &&
+			bodyManagesCallMap(fn.Body, receiverName(fn.Recv.List[0]), field) {
+			chunk.Metadata.Pattern = patternSingleflight
+		}
+	}
+
+	return chunk
+}
+
+func (c *Chunker) chunkType(fset *token.FileSet, src []byte, gd *ast.GenDecl, spec *ast.TypeSpec, ctx *chunkContext) Chunk {
+	kind := KindType
+	if _, ok := spec.Type.(*ast.InterfaceType); ok {
+		kind = KindInterface
+	}
+
+	// A single `type Foo struct {...}` decl's span includes the `type`
+	// keyword; in a grouped `type (...)` block that keyword and the
+	// surrounding parens are shared, so each spec contributes only its own
+	// span.
+	start, end := gd.Pos(), gd.End()
+	if len(gd.Specs) > 1 {
+		start, end = spec.Pos(), spec.End()
+	}
+
+	chunk := Chunk{
+		Kind:      kind,
+		Name:      spec.Name.Name,
+		StartLine: fset.Position(start).Line,
+		EndLine:   fset.Position(end).Line,
+		Source:    sliceSource(fset, src, start, end),
+	}
+
+	if spec.TypeParams != nil {
+		chunk.Metadata.TypeParams = typeParams(fset, src, spec.TypeParams)
+	}
+
+	if kind == KindInterface {
+		chunk.Metadata.ImplementedBy = ctx.rel.implementedBy[spec.Name.Name]
+	} else {
+		chunk.Metadata.LockGroup = siblingsExcept(ctx.lockGroups[spec.Name.Name], spec.Name.Name)
+		if _, ok := ctx.singleflight[spec.Name.Name]; ok {
+			chunk.Metadata.Pattern = patternSingleflight
+		}
+	}
+
+	return chunk
+}
+
+// typeParams extracts each entry of a generic type parameter list along with
+// its constraint.
+func typeParams(fset *token.FileSet, src []byte, fields *ast.FieldList) []TypeParam {
+	var params []TypeParam
+	for _, field := range fields.List {
+		constraint := sliceSource(fset, src, field.Type.Pos(), field.Type.End())
+		for _, name := range field.Names {
+			params = append(params, TypeParam{Name: name.Name, Constraint: constraint})
+		}
+	}
+	return params
+}
+
+// collectTypeParams indexes every generic type declaration's type parameter
+// list by type name, so generic methods -- which re-list parameter names at
+// the receiver but not their constraints -- can look up the constraints
+// from where the type was declared.
+func collectTypeParams(fset *token.FileSet, src []byte, file *ast.File) map[string][]TypeParam {
+	result := make(map[string][]TypeParam)
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, s := range gd.Specs {
+			spec, ok := s.(*ast.TypeSpec)
+			if !ok || spec.TypeParams == nil {
+				continue
+			}
+			result[spec.Name.Name] = typeParams(fset, src, spec.TypeParams)
+		}
+	}
+	return result
+}
+
+// receiverTypeParamNames extracts the type parameter identifiers named at a
+// generic method's receiver, e.g. the K, V in `func (c *Cache[K, V])`.
+func receiverTypeParamNames(expr ast.Expr) []string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return receiverTypeParamNames(t.X)
+	case *ast.IndexExpr:
+		if name, ok := t.Index.(*ast.Ident); ok {
+			return []string{name.Name}
+		}
+		return nil
+	case *ast.IndexListExpr:
+		var names []string
+		for _, idx := range t.Indices {
+			if name, ok := idx.(*ast.Ident); ok {
+				names = append(names, name.Name)
+			}
+		}
+		return names
+	default:
+		return nil
+	}
+}
+
+// receiverTypeParams pairs a generic method receiver's type parameter names
+// with the constraints from its type's declaration, positionally -- Go
+// receivers re-list parameter names but never constraints.
+func receiverTypeParams(names []string, declared []TypeParam) []TypeParam {
+	params := make([]TypeParam, 0, len(names))
+	for i, name := range names {
+		var constraint string
+		if i < len(declared) {
+			constraint = declared[i].Constraint
+		}
+		params = append(params, TypeParam{Name: name, Constraint: constraint})
+	}
+	return params
+}
+
+// sliceSource returns the source text spanning [start, end), using fset to
+// translate token positions into byte offsets within src.
+func sliceSource(fset *token.FileSet, src []byte, start, end token.Pos) string {
+	startOffset := fset.Position(start).Offset
+	endOffset := fset.Position(end).Offset
+	if startOffset < 0 || endOffset > len(src) || startOffset > endOffset {
+		return ""
+	}
+	return string(bytes.TrimSpace(src[startOffset:endOffset]))
+}
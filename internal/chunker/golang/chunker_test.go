@@ -0,0 +1,233 @@
+// SPDX-FileCopyrightText: 2025 Knitli Inc.
+// SPDX-License-Identifier: MIT OR Apache-2.0
+
+package golang
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func mustReadFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	src, err := os.ReadFile(filepath.Join("..", "..", "..", "tests", "fixtures", name))
+	if err != nil {
+		t.Fatalf("reading fixture %s: %v", name, err)
+	}
+	return src
+}
+
+func chunkByName(t *testing.T, chunks []Chunk, name string) Chunk {
+	t.Helper()
+	for _, c := range chunks {
+		if c.Name == name {
+			return c
+		}
+	}
+	t.Fatalf("no chunk named %q in %d chunks", name, len(chunks))
+	return Chunk{}
+}
+
+func TestChunkFile_GenericTypeParams(t *testing.T) {
+	src := mustReadFixture(t, "generic_cache.go")
+	chunks, err := New(ChunkerOptions{}).ChunkFile("generic_cache.go", src)
+	if err != nil {
+		t.Fatalf("ChunkFile: %v", err)
+	}
+
+	want := []TypeParam{{Name: "K", Constraint: "comparable"}, {Name: "V", Constraint: "any"}}
+
+	cache := chunkByName(t, chunks, "GenericCache")
+	if !slices.Equal(cache.Metadata.TypeParams, want) {
+		t.Errorf("GenericCache TypeParams = %+v, want %+v", cache.Metadata.TypeParams, want)
+	}
+
+	// Generic methods declare no type parameter list of their own -- they
+	// only re-list the receiver's names -- so their metadata must be
+	// recovered from where GenericCache was declared.
+	get := chunkByName(t, chunks, "Get")
+	if !slices.Equal(get.Metadata.TypeParams, want) {
+		t.Errorf("Get TypeParams = %+v, want %+v", get.Metadata.TypeParams, want)
+	}
+}
+
+func TestChunkFile_GroupedTypeDecl(t *testing.T) {
+	const src = `package regression
+
+type (
+	A struct {
+		Value int
+	}
+
+	B interface {
+		Do()
+	}
+)
+`
+	chunks, err := New(ChunkerOptions{}).ChunkFile("regression.go", []byte(src))
+	if err != nil {
+		t.Fatalf("ChunkFile: %v", err)
+	}
+
+	// Both specs of the grouped block must become their own chunk, not
+	// just the first.
+	chunkByName(t, chunks, "A")
+	chunkByName(t, chunks, "B")
+}
+
+func TestChunkFile_InterfaceImplementations(t *testing.T) {
+	src := mustReadFixture(t, "sample.go")
+	chunks, err := New(ChunkerOptions{}).ChunkFile("sample.go", src)
+	if err != nil {
+		t.Fatalf("ChunkFile: %v", err)
+	}
+
+	iface := chunkByName(t, chunks, "Processor")
+	if !slices.Contains(iface.Metadata.ImplementedBy, "DefaultProcessor") {
+		t.Errorf("Processor ImplementedBy = %v, want to contain DefaultProcessor", iface.Metadata.ImplementedBy)
+	}
+
+	process := chunkByName(t, chunks, "Process")
+	if !slices.Contains(process.Metadata.Implements, "Processor") {
+		t.Errorf("Process Implements = %v, want to contain Processor", process.Metadata.Implements)
+	}
+}
+
+func TestSatisfies_IgnoresParameterAndResultNames(t *testing.T) {
+	const src = `package regression
+
+type Reader interface {
+	Read(p []byte) (n int, err error)
+}
+
+type MyReader struct{}
+
+func (r MyReader) Read(buf []byte) (int, error) {
+	return 0, nil
+}
+`
+	chunks, err := New(ChunkerOptions{}).ChunkFile("regression.go", []byte(src))
+	if err != nil {
+		t.Fatalf("ChunkFile: %v", err)
+	}
+
+	iface := chunkByName(t, chunks, "Reader")
+	if !slices.Contains(iface.Metadata.ImplementedBy, "MyReader") {
+		t.Errorf("Reader ImplementedBy = %v, want MyReader despite differing parameter/result names", iface.Metadata.ImplementedBy)
+	}
+}
+
+func TestChunkerOptions_PreserveLockGroups(t *testing.T) {
+	src := mustReadFixture(t, "sample.go")
+	chunks, err := New(ChunkerOptions{PreserveLockGroups: true}).ChunkFile("sample.go", src)
+	if err != nil {
+		t.Fatalf("ChunkFile: %v", err)
+	}
+
+	cache := chunkByName(t, chunks, "Cache")
+	for _, want := range []string{"Get", "Set"} {
+		if !slices.Contains(cache.Metadata.LockGroup, want) {
+			t.Errorf("Cache LockGroup = %v, want to contain %q", cache.Metadata.LockGroup, want)
+		}
+	}
+
+	get := chunkByName(t, chunks, "Get")
+	for _, want := range []string{"Cache", "Set"} {
+		if !slices.Contains(get.Metadata.LockGroup, want) {
+			t.Errorf("Get LockGroup = %v, want to contain %q", get.Metadata.LockGroup, want)
+		}
+	}
+}
+
+func TestChunkerOptions_PreserveLockGroups_SyncMapAndAtomic(t *testing.T) {
+	const src = `package regression
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+type Stats struct {
+	seen sync.Map
+	hits atomic.Int64
+}
+
+func (s *Stats) Record(key string) {
+	s.seen.Store(key, true)
+	s.hits.Add(1)
+}
+`
+	chunks, err := New(ChunkerOptions{PreserveLockGroups: true}).ChunkFile("regression.go", []byte(src))
+	if err != nil {
+		t.Fatalf("ChunkFile: %v", err)
+	}
+
+	stats := chunkByName(t, chunks, "Stats")
+	if !slices.Contains(stats.Metadata.LockGroup, "Record") {
+		t.Errorf("Stats LockGroup = %v, want to contain Record", stats.Metadata.LockGroup)
+	}
+
+	record := chunkByName(t, chunks, "Record")
+	if !slices.Contains(record.Metadata.LockGroup, "Stats") {
+		t.Errorf("Record LockGroup = %v, want to contain Stats", record.Metadata.LockGroup)
+	}
+}
+
+func TestChunkerOptions_PreserveLockGroups_EmbeddedMutex(t *testing.T) {
+	const src = `package regression
+
+import "sync"
+
+type Q struct {
+	sync.Mutex
+	count int
+}
+
+func (q *Q) Inc() {
+	q.Lock()
+	defer q.Unlock()
+	q.count++
+}
+`
+	chunks, err := New(ChunkerOptions{PreserveLockGroups: true}).ChunkFile("regression.go", []byte(src))
+	if err != nil {
+		t.Fatalf("ChunkFile: %v", err)
+	}
+
+	q := chunkByName(t, chunks, "Q")
+	if !slices.Contains(q.Metadata.LockGroup, "Inc") {
+		t.Errorf("Q LockGroup = %v, want to contain Inc (promoted Lock/Unlock from embedded sync.Mutex)", q.Metadata.LockGroup)
+	}
+
+	inc := chunkByName(t, chunks, "Inc")
+	if !slices.Contains(inc.Metadata.LockGroup, "Q") {
+		t.Errorf("Inc LockGroup = %v, want to contain Q", inc.Metadata.LockGroup)
+	}
+}
+
+func TestChunkFile_SingleflightPattern(t *testing.T) {
+	src := mustReadFixture(t, "loader.go")
+	chunks, err := New(ChunkerOptions{}).ChunkFile("loader.go", src)
+	if err != nil {
+		t.Fatalf("ChunkFile: %v", err)
+	}
+
+	loader := chunkByName(t, chunks, "Loader")
+	if loader.Metadata.Pattern != patternSingleflight {
+		t.Errorf("Loader Pattern = %q, want %q", loader.Metadata.Pattern, patternSingleflight)
+	}
+
+	load := chunkByName(t, chunks, "Load")
+	if load.Metadata.Pattern != patternSingleflight {
+		t.Errorf("Load Pattern = %q, want %q", load.Metadata.Pattern, patternSingleflight)
+	}
+
+	// LoadMany spawns goroutines too, but it only fans out to Load -- it
+	// never touches the call map itself -- so it must not be tagged.
+	loadMany := chunkByName(t, chunks, "LoadMany")
+	if loadMany.Metadata.Pattern == patternSingleflight {
+		t.Errorf("LoadMany Pattern = %q, want not %q", loadMany.Metadata.Pattern, patternSingleflight)
+	}
+}
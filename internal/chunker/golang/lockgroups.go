@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: 2025 Knitli Inc.
+// SPDX-License-Identifier: MIT OR Apache-2.0
+
+package golang
+
+import (
+	"go/ast"
+	"go/token"
+	"sort"
+)
+
+// guardMethodNames are the method names that mark a method body as
+// accessing a guarded field: sync.Mutex/sync.RWMutex's Lock/Unlock pairs,
+// plus the access methods sync.Map and the sync/atomic types use instead of
+// locking (they guard by atomic access rather than mutual exclusion, but
+// the clustering need is the same).
+var guardMethodNames = map[string]bool{
+	"Lock":           true,
+	"Unlock":         true,
+	"RLock":          true,
+	"RUnlock":        true,
+	"Load":           true,
+	"Store":          true,
+	"Delete":         true,
+	"LoadOrStore":    true,
+	"LoadAndDelete":  true,
+	"Range":          true,
+	"Add":            true,
+	"Swap":           true,
+	"CompareAndSwap": true,
+}
+
+// guardedStruct records a struct's lock-guarded fields: its named fields
+// typed sync.Mutex/RWMutex/Map or atomic.*, plus whether it anonymously
+// embeds one of those types -- the standard `struct{ sync.Mutex; ... }`
+// idiom, which promotes Lock/Unlock/etc. onto the struct itself rather than
+// a named field.
+type guardedStruct struct {
+	fields   []string
+	embedded bool
+}
+
+// lockGroups computes, for every struct with a sync- or atomic-typed field,
+// the set of sibling chunk names -- the struct itself plus every method
+// whose body calls one of guardMethodNames on one of those fields -- that
+// ChunkerOptions.PreserveLockGroups keeps together. Structs with no guarding
+// methods are omitted; there is nothing to cluster them with.
+func lockGroups(file *ast.File) map[string][]string {
+	guarded := guardedFields(file)
+	if len(guarded) == 0 {
+		return nil
+	}
+
+	members := make(map[string]map[string]bool, len(guarded))
+	for structName := range guarded {
+		members[structName] = map[string]bool{structName: true}
+	}
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 {
+			continue
+		}
+		recv := receiverTypeName(fn.Recv.List[0].Type)
+		g, ok := guarded[recv]
+		if !ok || !bodyGuardsAny(fn.Body, receiverName(fn.Recv.List[0]), g) {
+			continue
+		}
+		members[recv][fn.Name.Name] = true
+	}
+
+	groups := make(map[string][]string, len(members))
+	for name, set := range members {
+		if len(set) <= 1 {
+			continue
+		}
+		names := make([]string, 0, len(set))
+		for n := range set {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		groups[name] = names
+	}
+
+	return groups
+}
+
+// receiverName returns the identifier a method's receiver is bound to, or
+// "" for an unnamed receiver (e.g. `func (Cache) String() string`).
+func receiverName(field *ast.Field) string {
+	if len(field.Names) == 0 {
+		return ""
+	}
+	return field.Names[0].Name
+}
+
+// guardedFields returns, for every struct type in file with a sync.Mutex,
+// sync.RWMutex, sync.Map, or atomic.* field -- named or anonymously
+// embedded -- that field's details.
+func guardedFields(file *ast.File) map[string]guardedStruct {
+	result := make(map[string]guardedStruct)
+
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, s := range gd.Specs {
+			spec, ok := s.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			st, ok := spec.Type.(*ast.StructType)
+			if !ok || st.Fields == nil {
+				continue
+			}
+
+			var g guardedStruct
+			for _, field := range st.Fields.List {
+				if !isLockFieldType(field.Type) {
+					continue
+				}
+				if len(field.Names) == 0 {
+					g.embedded = true
+					continue
+				}
+				for _, name := range field.Names {
+					g.fields = append(g.fields, name.Name)
+				}
+			}
+			if g.embedded || len(g.fields) > 0 {
+				result[spec.Name.Name] = g
+			}
+		}
+	}
+
+	return result
+}
+
+// isLockFieldType reports whether expr names sync.Mutex, sync.RWMutex,
+// sync.Map, or any type in the sync/atomic package.
+func isLockFieldType(expr ast.Expr) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	switch pkg.Name {
+	case "atomic":
+		return true
+	case "sync":
+		return sel.Sel.Name == "Mutex" || sel.Sel.Name == "RWMutex" || sel.Sel.Name == "Map"
+	default:
+		return false
+	}
+}
+
+// bodyGuardsAny reports whether body calls one of guardMethodNames on a
+// receiver field in g, e.g. `c.mu.Lock()` when "mu" is a sync.Mutex, or
+// `c.seen.Store(...)` when "seen" is a sync.Map. When g.embedded, it also
+// matches a promoted call straight on the receiver, e.g. `q.Lock()` when Q
+// anonymously embeds sync.Mutex; recvName is the receiver's bound
+// identifier ("q" in `func (q *Q) Inc()`).
+func bodyGuardsAny(body *ast.BlockStmt, recvName string, g guardedStruct) bool {
+	if body == nil {
+		return false
+	}
+
+	fieldSet := make(map[string]bool, len(g.fields))
+	for _, f := range g.fields {
+		fieldSet[f] = true
+	}
+
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		method, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || !guardMethodNames[method.Sel.Name] {
+			return true
+		}
+		switch x := method.X.(type) {
+		case *ast.SelectorExpr:
+			if id, ok := x.X.(*ast.Ident); ok && recvName != "" && id.Name == recvName && fieldSet[x.Sel.Name] {
+				found = true
+			}
+		case *ast.Ident:
+			if g.embedded && recvName != "" && x.Name == recvName {
+				found = true
+			}
+		}
+		return true
+	})
+
+	return found
+}
+
+// siblingsExcept returns group with self removed, or nil if that leaves
+// nothing -- the representation used for a chunk with no lock-group siblings.
+func siblingsExcept(group []string, self string) []string {
+	if len(group) == 0 {
+		return nil
+	}
+
+	siblings := make([]string, 0, len(group))
+	for _, name := range group {
+		if name != self {
+			siblings = append(siblings, name)
+		}
+	}
+	if len(siblings) == 0 {
+		return nil
+	}
+	return siblings
+}
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2025 Knitli Inc.
+// SPDX-License-Identifier: MIT OR Apache-2.0
+
+package golang
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// patternSingleflight tags a chunk that implements the coalesce-concurrent-
+// requests idiom: a struct with a mutex-guarded map[K]*call field, alongside
+// methods that fan a shared result out to waiters from a goroutine.
+const patternSingleflight = "singleflight"
+
+// singleflightTypes returns, for every struct type in file shaped like the
+// singleflight idiom, the name of its call-map field: a sync.Mutex guarding
+// a map from key to a pointer "call" bookkeeping type used to deduplicate
+// concurrent identical requests.
+func singleflightTypes(file *ast.File) map[string]string {
+	types := make(map[string]string)
+
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, s := range gd.Specs {
+			spec, ok := s.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			st, ok := spec.Type.(*ast.StructType)
+			if !ok || st.Fields == nil {
+				continue
+			}
+			if field := singleflightCallMapField(st); field != "" {
+				types[spec.Name.Name] = field
+			}
+		}
+	}
+
+	return types
+}
+
+// singleflightCallMapField returns the name of st's call-map field -- a
+// map[K]*call field alongside a sync.Mutex/RWMutex -- or "" if st isn't
+// shaped like the singleflight idiom.
+func singleflightCallMapField(st *ast.StructType) string {
+	hasMutex := false
+	callMapField := ""
+
+	for _, field := range st.Fields.List {
+		if sel, ok := field.Type.(*ast.SelectorExpr); ok {
+			if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "sync" &&
+				(sel.Sel.Name == "Mutex" || sel.Sel.Name == "RWMutex") {
+				hasMutex = true
+			}
+		}
+		if isCallMap(field.Type) && len(field.Names) > 0 {
+			callMapField = field.Names[0].Name
+		}
+	}
+
+	if !hasMutex {
+		return ""
+	}
+	return callMapField
+}
+
+// isCallMap reports whether expr is a map type whose value is a pointer
+// type, e.g. map[string]*call -- the bookkeeping shape singleflight
+// implementations use to track in-flight requests per key.
+func isCallMap(expr ast.Expr) bool {
+	m, ok := expr.(*ast.MapType)
+	if !ok {
+		return false
+	}
+	_, ok = m.Value.(*ast.StarExpr)
+	return ok
+}
+
+// bodySpawnsGoroutine reports whether body contains a go statement, the
+// fan-out mechanism singleflight callers use to deliver a shared result to
+// concurrent waiters.
+func bodySpawnsGoroutine(body *ast.BlockStmt) bool {
+	if body == nil {
+		return false
+	}
+
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		if _, ok := n.(*ast.GoStmt); ok {
+			found = true
+			return false
+		}
+		return true
+	})
+
+	return found
+}
+
+// bodyManagesCallMap reports whether body indexes or deletes from the
+// receiver's call-map field directly, e.g. `l.calls[key]` or
+// `delete(l.calls, key)`. A method that merely calls another method
+// spawning a goroutine -- LoadMany fanning out to Load, say -- never
+// touches the map itself and so isn't the one that owns the coalescing.
+func bodyManagesCallMap(body *ast.BlockStmt, recvName, field string) bool {
+	if body == nil || recvName == "" || field == "" {
+		return false
+	}
+
+	isCallMapSelector := func(expr ast.Expr) bool {
+		sel, ok := expr.(*ast.SelectorExpr)
+		if !ok {
+			return false
+		}
+		id, ok := sel.X.(*ast.Ident)
+		return ok && id.Name == recvName && sel.Sel.Name == field
+	}
+
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		switch e := n.(type) {
+		case *ast.IndexExpr:
+			if isCallMapSelector(e.X) {
+				found = true
+				return false
+			}
+		case *ast.CallExpr:
+			if id, ok := e.Fun.(*ast.Ident); ok && id.Name == "delete" && len(e.Args) > 0 && isCallMapSelector(e.Args[0]) {
+				found = true
+				return false
+			}
+		}
+		return true
+	})
+
+	return found
+}
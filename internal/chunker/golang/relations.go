@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: 2025 Knitli Inc.
+// SPDX-License-Identifier: MIT OR Apache-2.0
+
+package golang
+
+import (
+	"go/ast"
+	"go/token"
+	"sort"
+	"strings"
+)
+
+// methodSig is a structural signature for a method: its name plus its
+// parameter and result types with names dropped, matching Go's own
+// interface satisfaction rule (parameter and result names never affect
+// whether a method set satisfies an interface). Two methods with equal
+// methodSigs are considered interchangeable for interface satisfaction.
+type methodSig struct {
+	name    string
+	params  string
+	results string
+}
+
+// relations is the cross-reference between concrete types and the
+// interfaces they structurally satisfy, computed once per file.
+type relations struct {
+	implements    map[string][]string
+	implementedBy map[string][]string
+}
+
+// symbolTable holds the per-file interface and concrete method sets needed
+// to compute relations.
+type symbolTable struct {
+	interfaces map[string][]methodSig
+	methods    map[string][]methodSig
+}
+
+// newSymbolTable runs a lightweight per-file symbol pass: it collects
+// interface method sets by name and collects concrete method sets by
+// receiver type, leaving structural satisfaction to implementations.
+func newSymbolTable(fset *token.FileSet, src []byte, file *ast.File) *symbolTable {
+	st := &symbolTable{
+		interfaces: make(map[string][]methodSig),
+		methods:    make(map[string][]methodSig),
+	}
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil || len(d.Recv.List) == 0 {
+				continue
+			}
+			recv := receiverTypeName(d.Recv.List[0].Type)
+			st.methods[recv] = append(st.methods[recv], funcSig(fset, src, d.Name.Name, d.Type))
+		case *ast.GenDecl:
+			if d.Tok != token.TYPE {
+				continue
+			}
+			for _, s := range d.Specs {
+				spec, ok := s.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+				iface, ok := spec.Type.(*ast.InterfaceType)
+				if !ok {
+					continue
+				}
+				st.interfaces[spec.Name.Name] = interfaceMethodSigs(fset, src, iface)
+			}
+		}
+	}
+
+	return st
+}
+
+// implementations computes, for every (concrete type, interface) pair in the
+// table, whether the concrete type structurally satisfies the interface --
+// all interface methods present on the type with matching signatures -- and
+// returns the relation in both directions.
+func (st *symbolTable) implementations() *relations {
+	rel := &relations{
+		implements:    make(map[string][]string),
+		implementedBy: make(map[string][]string),
+	}
+
+	for typeName, methods := range st.methods {
+		for ifaceName, ifaceMethods := range st.interfaces {
+			if !satisfies(ifaceMethods, methods) {
+				continue
+			}
+			rel.implements[typeName] = append(rel.implements[typeName], ifaceName)
+			rel.implementedBy[ifaceName] = append(rel.implementedBy[ifaceName], typeName)
+		}
+	}
+
+	for _, names := range rel.implements {
+		sort.Strings(names)
+	}
+	for _, names := range rel.implementedBy {
+		sort.Strings(names)
+	}
+
+	return rel
+}
+
+// satisfies reports whether methods contains, for every method in iface, one
+// with the same name and the same parameter and result types. An empty iface
+// is satisfied by nothing. iface holds only the methods declared directly in
+// the interface body; methods of embedded interfaces are not checked.
+func satisfies(iface, methods []methodSig) bool {
+	if len(iface) == 0 {
+		return false
+	}
+	for _, want := range iface {
+		found := false
+		for _, have := range methods {
+			if have == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func funcSig(fset *token.FileSet, src []byte, name string, ft *ast.FuncType) methodSig {
+	return methodSig{
+		name:    name,
+		params:  fieldTypes(fset, src, ft.Params),
+		results: fieldTypes(fset, src, ft.Results),
+	}
+}
+
+// fieldTypes renders fields as a comma-separated list of its parameter or
+// result *types*, expanding grouped names (`a, b int`) to one entry per
+// name so the count lines up with the actual parameter count, and dropping
+// every name -- `(buf []byte)` and `(p []byte)` render identically.
+func fieldTypes(fset *token.FileSet, src []byte, fields *ast.FieldList) string {
+	if fields == nil {
+		return ""
+	}
+
+	var types []string
+	for _, field := range fields.List {
+		typ := normalizeSig(sliceSource(fset, src, field.Type.Pos(), field.Type.End()))
+		n := len(field.Names)
+		if n == 0 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			types = append(types, typ)
+		}
+	}
+	return strings.Join(types, ", ")
+}
+
+func interfaceMethodSigs(fset *token.FileSet, src []byte, iface *ast.InterfaceType) []methodSig {
+	if iface.Methods == nil {
+		return nil
+	}
+
+	var sigs []methodSig
+	for _, field := range iface.Methods.List {
+		ft, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) == 0 {
+			continue
+		}
+		for _, name := range field.Names {
+			sigs = append(sigs, funcSig(fset, src, name.Name, ft))
+		}
+	}
+	return sigs
+}
+
+// receiverTypeName strips pointer and generic instantiation syntax to find
+// the declared name of a method receiver's type, e.g. `*Cache[K, V]` -> `Cache`.
+func receiverTypeName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return receiverTypeName(t.X)
+	case *ast.IndexExpr:
+		return receiverTypeName(t.X)
+	case *ast.IndexListExpr:
+		return receiverTypeName(t.X)
+	case *ast.Ident:
+		return t.Name
+	default:
+		return ""
+	}
+}
+
+func normalizeSig(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
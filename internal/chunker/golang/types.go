@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2025 Knitli Inc.
+// SPDX-License-Identifier: MIT OR Apache-2.0
+
+package golang
+
+// ChunkKind identifies the syntactic category of a Chunk.
+type ChunkKind int
+
+const (
+	// KindFunc is a free function declaration.
+	KindFunc ChunkKind = iota
+	// KindMethod is a function declaration with a receiver.
+	KindMethod
+	// KindType is a non-interface type declaration.
+	KindType
+	// KindInterface is an interface type declaration.
+	KindInterface
+)
+
+// Chunk is a single semantically coherent unit of Go source extracted from a
+// file, along with metadata that lets downstream embedding and search relate
+// it to other chunks without re-parsing the source.
+type Chunk struct {
+	Kind      ChunkKind
+	Name      string
+	StartLine int
+	EndLine   int
+	Source    string
+	Metadata  Metadata
+}
+
+// Metadata carries cross-cutting information attached to a Chunk beyond its
+// raw source.
+type Metadata struct {
+	// TypeParams lists the chunk's generic type parameters and their
+	// constraints, in declaration order. Empty for non-generic chunks.
+	TypeParams []TypeParam
+
+	// Implements lists the interfaces a method's receiver type structurally
+	// satisfies. Set on method chunks; empty otherwise.
+	Implements []string
+
+	// ImplementedBy lists the concrete types that structurally satisfy an
+	// interface. Set on interface chunks; empty otherwise.
+	ImplementedBy []string
+
+	// LockGroup lists the sibling chunks -- a struct and the methods that
+	// acquire one of its sync/atomic-typed fields -- that ChunkerOptions
+	// .PreserveLockGroups keeps clustered together. Empty unless that
+	// option is set and the chunk participates in such a group.
+	LockGroup []string
+
+	// Pattern names a recognized concurrency idiom the chunk participates
+	// in, e.g. "singleflight". Empty unless a pattern was recognized.
+	Pattern string
+}
+
+// TypeParam describes a single entry in a generic declaration's type
+// parameter list, e.g. the `K comparable` in `Cache[K comparable, V any]`.
+type TypeParam struct {
+	Name       string
+	Constraint string
+}
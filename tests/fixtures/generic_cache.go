@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2025 Knitli Inc.
+// SPDX-License-Identifier: MIT OR Apache-2.0
+
+package fixtures
+
+import (
+	"sync"
+	"time"
+)
+
+// EvictionReason describes why an entry left a GenericCache.
+type EvictionReason int
+
+const (
+	// EvictionReasonExpired means the entry's TTL elapsed.
+	EvictionReasonExpired EvictionReason = iota
+	// EvictionReasonCapacity means the entry was evicted to make room for another.
+	EvictionReasonCapacity
+	// EvictionReasonExplicit means the entry was removed by a caller.
+	EvictionReasonExplicit
+)
+
+// EvictionCallback is invoked whenever an entry leaves a GenericCache, in the
+// style of otter's typed eviction hooks.
+type EvictionCallback[K comparable, V any] func(key K, value V, reason EvictionReason)
+
+// GenericCache is a typed, thread-safe cache with TTL support, mirroring the
+// shape of otter's Cache[K comparable, V any].
+type GenericCache[K comparable, V any] struct {
+	mu      sync.RWMutex
+	storage map[K]genericCacheEntry[V]
+	ttl     time.Duration
+	onEvict EvictionCallback[K, V]
+}
+
+type genericCacheEntry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// GenericCacheBuilder configures and constructs a GenericCache.
+type GenericCacheBuilder[K comparable, V any] struct {
+	ttl     time.Duration
+	onEvict EvictionCallback[K, V]
+}
+
+// NewGenericCacheBuilder creates a builder for a GenericCache with the given TTL.
+func NewGenericCacheBuilder[K comparable, V any](ttl time.Duration) *GenericCacheBuilder[K, V] {
+	return &GenericCacheBuilder[K, V]{ttl: ttl}
+}
+
+// WithEvictionCallback registers a callback invoked on every eviction.
+func (b *GenericCacheBuilder[K, V]) WithEvictionCallback(cb EvictionCallback[K, V]) *GenericCacheBuilder[K, V] {
+	b.onEvict = cb
+	return b
+}
+
+// Build constructs the configured GenericCache.
+func (b *GenericCacheBuilder[K, V]) Build() *GenericCache[K, V] {
+	return &GenericCache[K, V]{
+		storage: make(map[K]genericCacheEntry[V]),
+		ttl:     b.ttl,
+		onEvict: b.onEvict,
+	}
+}
+
+// Get retrieves a value from the cache if present and not expired.
+func (c *GenericCache[K, V]) Get(key K) (V, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, exists := c.storage[key]
+	if !exists {
+		var zero V
+		return zero, false
+	}
+
+	if time.Now().After(entry.expiresAt) {
+		var zero V
+		return zero, false
+	}
+
+	return entry.value, true
+}
+
+// Set stores a value in the cache under the configured TTL.
+func (c *GenericCache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.storage[key] = genericCacheEntry[V]{
+		value:     value,
+		expiresAt: time.Now().Add(c.ttl),
+	}
+}
+
+// Delete removes a key from the cache, invoking the eviction callback if one
+// is registered and the key was present.
+func (c *GenericCache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	entry, exists := c.storage[key]
+	if exists {
+		delete(c.storage, key)
+	}
+	c.mu.Unlock()
+
+	if exists && c.onEvict != nil {
+		c.onEvict(key, entry.value, EvictionReasonExplicit)
+	}
+}
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2025 Knitli Inc.
+// SPDX-License-Identifier: MIT OR Apache-2.0
+
+package fixtures
+
+import "sync"
+
+// FetchFunc fetches the value for a single key from a downstream source.
+type FetchFunc func(key string) (string, error)
+
+// call tracks a single in-flight Load for a key, so concurrent callers asking
+// for the same key share one downstream fetch instead of triggering their own.
+type call struct {
+	wg  sync.WaitGroup
+	val string
+	err error
+}
+
+// Loader coalesces concurrent identical requests: while a key's fetch is in
+// flight, additional callers for that key wait on the same result instead of
+// starting another downstream fetch.
+type Loader struct {
+	mu    sync.Mutex
+	calls map[string]*call
+	fetch FetchFunc
+}
+
+// NewLoader creates a Loader that fetches values using fetch.
+func NewLoader(fetch FetchFunc) *Loader {
+	return &Loader{
+		calls: make(map[string]*call),
+		fetch: fetch,
+	}
+}
+
+// Load fetches the value for key, coalescing concurrent calls for the same
+// key into a single downstream fetch.
+func (l *Loader) Load(key string) (string, error) {
+	l.mu.Lock()
+	if c, ok := l.calls[key]; ok {
+		l.mu.Unlock()
+		c.wg.Wait()
+		return c.val, c.err
+	}
+
+	c := new(call)
+	c.wg.Add(1)
+	l.calls[key] = c
+	l.mu.Unlock()
+
+	go func() {
+		defer c.wg.Done()
+		c.val, c.err = l.fetch(key)
+
+		l.mu.Lock()
+		delete(l.calls, key)
+		l.mu.Unlock()
+	}()
+
+	c.wg.Wait()
+	return c.val, c.err
+}
+
+// LoadMany fetches values for all the given keys concurrently via Load, so
+// duplicate keys whose fetches overlap in flight share one downstream fetch.
+func (l *Loader) LoadMany(keys []string) (map[string]string, error) {
+	results := make(map[string]string, len(keys))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var firstErr error
+
+	for _, key := range keys {
+		wg.Add(1)
+		go func(k string) {
+			defer wg.Done()
+
+			val, err := l.Load(k)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			results[k] = val
+		}(key)
+	}
+
+	wg.Wait()
+	return results, firstErr
+}
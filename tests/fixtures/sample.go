@@ -115,4 +115,3 @@ func (p *DefaultProcessor) ProcessBatch(items []DataItem) ([]DataItem, error) {
     wg.Wait()
     return results, nil
 }
-─────┴──────────────────────────────────────────────────────────────────────────